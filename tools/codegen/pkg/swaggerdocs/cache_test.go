@@ -0,0 +1,188 @@
+package swaggerdocs
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+const validTypesSrc = `package v1
+
+// Widget is a simple documented type.
+type Widget struct {
+	// Name is the widget's name.
+	Name string
+}
+`
+
+func writeTypesFile(t *testing.T, dir, src string) {
+	t.Helper()
+	if err := writeFile(filepath.Join(dir, "types.go"), src); err != nil {
+		t.Fatalf("writeTypesFile: %v", err)
+	}
+}
+
+func writeFile(path, content string) error {
+	return ioutil.WriteFile(path, []byte(content), 0644)
+}
+
+func TestCacheGetPutRoundTrip(t *testing.T) {
+	cache, err := NewCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+
+	if _, ok := cache.Get("missing"); ok {
+		t.Fatal("Get on empty cache reported a hit")
+	}
+
+	want := []byte("package v1\n// generated\n")
+	if err := cache.Put("key1", want); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	got, ok := cache.Get("key1")
+	if !ok {
+		t.Fatal("Get after Put reported a miss")
+	}
+	if string(got) != string(want) {
+		t.Errorf("Get = %q, want %q", got, want)
+	}
+}
+
+func TestCacheKeyChangesWithContent(t *testing.T) {
+	cache, err := NewCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "types.go")
+	writeFile(path, validTypesSrc)
+
+	key1, err := cache.Key([]string{path})
+	if err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+
+	writeFile(path, validTypesSrc+"\n// a change\n")
+	key2, err := cache.Key([]string{path})
+	if err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+
+	if key1 == key2 {
+		t.Fatal("Key did not change when input content changed")
+	}
+}
+
+func TestCacheKeyChangesWithToolVersion(t *testing.T) {
+	cache, err := NewCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "types.go")
+	writeFile(path, validTypesSrc)
+
+	key1, err := cache.Key([]string{path})
+	if err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+
+	old := toolVersion
+	toolVersion = old + "-next"
+	defer func() { toolVersion = old }()
+
+	key2, err := cache.Key([]string{path})
+	if err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+
+	if key1 == key2 {
+		t.Fatal("Key did not change when toolVersion changed")
+	}
+}
+
+func TestGenerateAllCacheHit(t *testing.T) {
+	pkgDir := t.TempDir()
+	writeTypesFile(t, pkgDir, validTypesSrc)
+
+	cacheDir := t.TempDir()
+	cache, err := NewCache(cacheDir)
+	if err != nil {
+		t.Fatalf("NewCache: %v", err)
+	}
+
+	pkgs := []Package{{PackageName: "v1", Dir: pkgDir}}
+
+	results, err := GenerateAll(pkgs, cache, 1)
+	if err != nil {
+		t.Fatalf("GenerateAll (first run): %v", err)
+	}
+	generated := results[0]
+
+	// Replace the cache entry with a sentinel so a second run can only
+	// return this value by reading the cache, not by regenerating.
+	matches, err := filepath.Glob(filepath.Join(pkgDir, typesGlob))
+	if err != nil {
+		t.Fatalf("Glob: %v", err)
+	}
+	key, err := cache.Key(matches)
+	if err != nil {
+		t.Fatalf("Key: %v", err)
+	}
+	sentinel := []byte("package v1\n// cached sentinel\n")
+	if err := cache.Put(key, sentinel); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+
+	results, err = GenerateAll(pkgs, cache, 1)
+	if err != nil {
+		t.Fatalf("GenerateAll (second run): %v", err)
+	}
+	if string(results[0]) != string(sentinel) {
+		t.Errorf("GenerateAll returned %q, want the cached sentinel %q (cache was not consulted)", results[0], sentinel)
+	}
+	_ = generated
+}
+
+func TestGenerateAllConcurrentNoCache(t *testing.T) {
+	var pkgs []Package
+	for i := 0; i < 5; i++ {
+		dir := t.TempDir()
+		writeTypesFile(t, dir, validTypesSrc)
+		pkgs = append(pkgs, Package{PackageName: "v1", Dir: dir})
+	}
+
+	results, err := GenerateAll(pkgs, nil, 2)
+	if err != nil {
+		t.Fatalf("GenerateAll: %v", err)
+	}
+	if len(results) != len(pkgs) {
+		t.Fatalf("len(results) = %d, want %d", len(results), len(pkgs))
+	}
+	for i, data := range results {
+		if len(data) == 0 {
+			t.Errorf("results[%d] is empty", i)
+		}
+	}
+}
+
+func TestGenerateAllPropagatesError(t *testing.T) {
+	badDir := t.TempDir()
+	writeTypesFile(t, badDir, "this is not valid go source {{{")
+
+	goodDir := t.TempDir()
+	writeTypesFile(t, goodDir, validTypesSrc)
+
+	pkgs := []Package{
+		{PackageName: "v1", Dir: goodDir},
+		{PackageName: "v1", Dir: badDir},
+	}
+
+	if _, err := GenerateAll(pkgs, nil, 2); err == nil {
+		t.Fatal("GenerateAll returned nil error for a package with invalid Go source")
+	}
+}
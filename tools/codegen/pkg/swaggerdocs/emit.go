@@ -0,0 +1,102 @@
+package swaggerdocs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
+)
+
+// DocEmitter writes the parsed documentation for a package to w in some
+// serialized form. Implementations must not mutate docs.
+type DocEmitter interface {
+	// Emit serializes docs for the given package to w.
+	Emit(pkg string, docs []kruntime.KubeTypes, w io.Writer) error
+}
+
+// docField is the flattened, serializable representation of a single
+// documented field used by the json and yaml emitters.
+type docField struct {
+	Type    string `json:"type"`
+	Field   string `json:"field"`
+	Comment string `json:"comment"`
+}
+
+// docType groups the documented fields belonging to a single Go type.
+type docType struct {
+	Type   string     `json:"type"`
+	Fields []docField `json:"fields"`
+}
+
+// docPackage is the top-level shape written by the json and yaml emitters.
+type docPackage struct {
+	Package string    `json:"package"`
+	Types   []docType `json:"types"`
+}
+
+// flattenDocs walks docs and produces the docPackage representation shared
+// by the json and yaml emitters. Each kruntime.KubeTypes entry has the type
+// name and its own comment as its first Pair, followed by one Pair per
+// documented field, matching the shape kruntime.WriteSwaggerDocFunc
+// consumes.
+func flattenDocs(pkg string, docs []kruntime.KubeTypes) docPackage {
+	out := docPackage{Package: pkg}
+	for _, kt := range docs {
+		if len(kt) == 0 {
+			continue
+		}
+		typeName := kt[0].Name
+		dt := docType{Type: typeName}
+		for _, row := range kt[1:] {
+			dt.Fields = append(dt.Fields, docField{
+				Type:    typeName,
+				Field:   row.Name,
+				Comment: row.Doc,
+			})
+		}
+		out.Types = append(out.Types, dt)
+	}
+	return out
+}
+
+// jsonEmitter emits documentation as indented JSON.
+type jsonEmitter struct{}
+
+// Emit implements DocEmitter.
+func (jsonEmitter) Emit(pkg string, docs []kruntime.KubeTypes, w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(flattenDocs(pkg, docs)); err != nil {
+		return fmt.Errorf("error encoding swagger docs as json: %w", err)
+	}
+	return nil
+}
+
+// yamlEmitter emits documentation as YAML.
+type yamlEmitter struct{}
+
+// Emit implements DocEmitter.
+func (yamlEmitter) Emit(pkg string, docs []kruntime.KubeTypes, w io.Writer) error {
+	out, err := yaml.Marshal(flattenDocs(pkg, docs))
+	if err != nil {
+		return fmt.Errorf("error encoding swagger docs as yaml: %w", err)
+	}
+	_, err = w.Write(out)
+	return err
+}
+
+// emittersByOutputType maps an OutputTypes entry to the DocEmitter that
+// produces it. The "go" output type is handled separately by
+// generateSwaggerDocs since it is not a plain serialization of docPackage.
+var emittersByOutputType = map[string]DocEmitter{
+	"json": jsonEmitter{},
+	"yaml": yamlEmitter{},
+}
+
+// outputFileName returns the file name writeSwaggerDocs uses
+// for a given non-go output type, e.g. "json" -> "docs.json".
+func outputFileName(outputType string) string {
+	return fmt.Sprintf("docs.%s", outputType)
+}
@@ -0,0 +1,86 @@
+package swaggerdocs
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+)
+
+func TestFlattenDocs(t *testing.T) {
+	docs := []kruntime.KubeTypes{
+		{
+			{Name: "Pod", Doc: "Pod is a collection of containers."},
+			{Name: "Spec", Doc: "Spec defines the desired behavior."},
+			{Name: "Status", Doc: "Status is the observed state."},
+		},
+		{}, // empty entries must be skipped
+	}
+
+	got := flattenDocs("v1", docs)
+
+	if got.Package != "v1" {
+		t.Fatalf("Package = %q, want %q", got.Package, "v1")
+	}
+	if len(got.Types) != 1 {
+		t.Fatalf("len(Types) = %d, want 1", len(got.Types))
+	}
+
+	pod := got.Types[0]
+	if pod.Type != "Pod" {
+		t.Fatalf("Types[0].Type = %q, want %q", pod.Type, "Pod")
+	}
+	wantFields := []docField{
+		{Type: "Pod", Field: "Spec", Comment: "Spec defines the desired behavior."},
+		{Type: "Pod", Field: "Status", Comment: "Status is the observed state."},
+	}
+	if len(pod.Fields) != len(wantFields) {
+		t.Fatalf("len(Fields) = %d, want %d", len(pod.Fields), len(wantFields))
+	}
+	for i, want := range wantFields {
+		if pod.Fields[i] != want {
+			t.Errorf("Fields[%d] = %+v, want %+v", i, pod.Fields[i], want)
+		}
+	}
+}
+
+func TestJSONEmitterEmit(t *testing.T) {
+	docs := []kruntime.KubeTypes{
+		{
+			{Name: "Pod", Doc: "Pod doc."},
+			{Name: "Spec", Doc: "Spec doc."},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (jsonEmitter{}).Emit("v1", docs, &buf); err != nil {
+		t.Fatalf("Emit: %v", err)
+	}
+
+	var got docPackage
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Unmarshal output: %v", err)
+	}
+	want := flattenDocs("v1", docs)
+	if got.Package != want.Package || len(got.Types) != len(want.Types) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestYAMLEmitterEmit(t *testing.T) {
+	docs := []kruntime.KubeTypes{
+		{
+			{Name: "Pod", Doc: "Pod doc."},
+			{Name: "Spec", Doc: "Spec doc."},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := (yamlEmitter{}).Emit("v1", docs, &buf); err != nil {
+		t.Fatalf("Emit: %v", err)
+	}
+	if buf.Len() == 0 {
+		t.Fatal("Emit wrote no output")
+	}
+}
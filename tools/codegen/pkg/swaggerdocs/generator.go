@@ -0,0 +1,296 @@
+package swaggerdocs
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+)
+
+// ParseDocumentationFromReaders parses the Go source read from each of srcs
+// and returns the combined KubeTypes documentation. This is the in-process
+// equivalent of globbing typesGlob and reading the matched files from disk,
+// and lets callers supply sources that don't exist on disk (stdin, generated
+// buffers, test fixtures).
+func ParseDocumentationFromReaders(srcs []io.Reader) ([]kruntime.KubeTypes, error) {
+	docs, _, err := ParseDocumentationFromReadersWithResolvers(srcs, nil)
+	return docs, err
+}
+
+// FieldType is the resolved OpenAPI type for a struct field that
+// ParseDocumentationFromReadersWithResolvers could classify, keyed by
+// "TypeName.FieldName" in the map it returns.
+type FieldType struct {
+	GoType      string
+	OpenAPIType string
+}
+
+// ParseDocumentationFromReadersWithResolvers is ParseDocumentationFromReaders
+// plus type classification: it also returns a map of "TypeName.FieldName" to
+// the resolved FieldType for every field whose Go type is interface{}/any or
+// a type resolvers can name. resolvers are consulted in order for every
+// field's rendered Go type (a generic instantiation is rendered as e.g.
+// "List[string]"); nil disables custom resolution and falls back to the
+// built-in interface{}/any -> "object" rule. Generic instantiations have no
+// built-in rule, so they get an entry only when a resolver names them.
+func ParseDocumentationFromReadersWithResolvers(srcs []io.Reader, resolvers []TypeResolver) ([]kruntime.KubeTypes, map[string]FieldType, error) {
+	docs, fieldTypes, _, err := ParseDocumentationFromReadersWithPolicy(srcs, resolvers)
+	return docs, fieldTypes, err
+}
+
+// ParseDocumentationFromReadersWithPolicy is
+// ParseDocumentationFromReadersWithResolvers plus the raw, unstripped field
+// comments enforcePolicy needs to check house style (TODO markers, ---
+// cutoffs, and so on) before kruntime.WriteSwaggerDocFunc strips them. The
+// returned map is keyed by "TypeName.FieldName", same as fieldTypes.
+//
+// Struct declarations are collected across all of srcs before any flattening
+// happens, so a type embedded from a sibling source (as happens whenever a
+// group-version package's types are split across several types_*.go files)
+// is flattened just as if it had been declared in the same file.
+func ParseDocumentationFromReadersWithPolicy(srcs []io.Reader, resolvers []TypeResolver) ([]kruntime.KubeTypes, map[string]FieldType, map[string]string, error) {
+	structs := map[string]*ast.StructType{}
+	docs := map[string]*ast.CommentGroup{}
+	var order []string
+	for i, src := range srcs {
+		data, err := ioutil.ReadAll(src)
+		if err != nil {
+			return nil, nil, nil, fmt.Errorf("error reading source %d: %w", i, err)
+		}
+
+		if err := collectStructsFromSource(data, structs, docs, &order); err != nil {
+			return nil, nil, nil, fmt.Errorf("error parsing source %d: %w", i, err)
+		}
+	}
+
+	fieldTypes := map[string]FieldType{}
+	rawFieldDocs := map[string]string{}
+	var kubeTypes []kruntime.KubeTypes
+	for _, name := range order {
+		kt := kruntime.KubeTypes{{Name: name, Doc: commentText(docs[name])}}
+		kt = append(kt, flattenFields(name, structs[name], structs, resolvers, fieldTypes, rawFieldDocs, map[string]bool{name: true})...)
+		kubeTypes = append(kubeTypes, kt)
+	}
+	return kubeTypes, fieldTypes, rawFieldDocs, nil
+}
+
+// collectStructsFromSource walks the struct type declarations in src and
+// records each one's *ast.StructType and doc comment into structs and docs
+// (keyed by type name), appending its name to *order. Called once per source
+// so that structs and docs accumulate across every source before flattening
+// runs, letting a type embed a struct declared in a sibling source.
+func collectStructsFromSource(src []byte, structs map[string]*ast.StructType, docs map[string]*ast.CommentGroup, order *[]string) error {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return fmt.Errorf("error parsing go source: %w", err)
+	}
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			structs[typeSpec.Name.Name] = structType
+			if typeSpec.Doc != nil {
+				docs[typeSpec.Name.Name] = typeSpec.Doc
+			} else {
+				docs[typeSpec.Name.Name] = genDecl.Doc
+			}
+			*order = append(*order, typeSpec.Name.Name)
+		}
+	}
+
+	return nil
+}
+
+// flattenFields returns the {FieldName, doc} Pairs for structType, inlining
+// the fields of any anonymous (embedded) member whose type is itself defined
+// in structs, regardless of which source it was declared in. seen guards
+// against embedding cycles.
+func flattenFields(typeName string, structType *ast.StructType, structs map[string]*ast.StructType, resolvers []TypeResolver, fieldTypes map[string]FieldType, rawFieldDocs map[string]string, seen map[string]bool) []kruntime.Pair {
+	if structType == nil {
+		return nil
+	}
+
+	var rows []kruntime.Pair
+	for _, field := range structType.Fields.List {
+		if len(field.Names) == 0 {
+			embeddedName := exprString(field.Type)
+			embeddedName = strings.TrimPrefix(embeddedName, "*")
+			if embedded, ok := structs[embeddedName]; ok && !seen[embeddedName] {
+				seen[embeddedName] = true
+				rows = append(rows, flattenFields(typeName, embedded, structs, resolvers, fieldTypes, rawFieldDocs, seen)...)
+				continue
+			}
+			// Embedded type isn't locally defined (e.g. metav1.TypeMeta);
+			// its fields aren't visible without cross-package parsing, so it
+			// is documented under its own type name like any other field.
+			rows = append(rows, kruntime.Pair{Name: embeddedName, Doc: commentText(field.Doc)})
+			continue
+		}
+
+		doc := commentText(field.Doc)
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			rows = append(rows, kruntime.Pair{Name: name.Name, Doc: doc})
+			rawFieldDocs[typeName+"."+name.Name] = doc
+			if openAPIType := resolveType(field.Type, resolvers); openAPIType != "" {
+				fieldTypes[typeName+"."+name.Name] = FieldType{
+					GoType:      exprString(field.Type),
+					OpenAPIType: openAPIType,
+				}
+			}
+		}
+	}
+	return rows
+}
+
+// commentText returns the trimmed text of a doc comment group, or "" if cg
+// is nil.
+func commentText(cg *ast.CommentGroup) string {
+	if cg == nil {
+		return ""
+	}
+	return strings.TrimSpace(cg.Text())
+}
+
+// Generator produces swagger documentation from already-parsed KubeTypes
+// without touching disk, so it can be embedded in other generators. The
+// on-disk types*.go glob behavior is layered on top of it by
+// generateSwaggerDocsFromGlob, which reads the matching files and hands the
+// result to NewGenerator.
+type Generator struct {
+	packageName  string
+	docsForTypes []kruntime.KubeTypes
+}
+
+// NewGenerator returns a Generator for packageName that documents
+// docsForTypes.
+func NewGenerator(packageName string, docsForTypes []kruntime.KubeTypes) *Generator {
+	return &Generator{packageName: packageName, docsForTypes: docsForTypes}
+}
+
+// WriteTo writes the requested outputType's documentation to w. outputType
+// is GoOutputType or a key registered in emittersByOutputType.
+func (g *Generator) WriteTo(outputType string, w io.Writer) error {
+	if outputType == GoOutputType {
+		data, err := generateSwaggerDocs(g.packageName, g.docsForTypes)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+
+	emitter, ok := emittersByOutputType[outputType]
+	if !ok {
+		return fmt.Errorf("unknown swagger doc output type %q", outputType)
+	}
+	return emitter.Emit(g.packageName, g.docsForTypes, w)
+}
+
+// Verifier verifies already-parsed KubeTypes documentation against an
+// existing rendering read from an io.Reader, without touching disk.
+type Verifier struct {
+	packageName     string
+	docsForTypes    []kruntime.KubeTypes
+	enforceComments bool
+	rawFieldDocs    map[string]string
+	cfg             PolicyConfig
+}
+
+// NewVerifier returns a Verifier for packageName that checks docsForTypes,
+// failing on missing field docs when enforceComments is set.
+func NewVerifier(packageName string, docsForTypes []kruntime.KubeTypes, enforceComments bool) *Verifier {
+	return &Verifier{packageName: packageName, docsForTypes: docsForTypes, enforceComments: enforceComments}
+}
+
+// NewVerifierWithPolicy is NewVerifier plus cfg's house-style rules, applied
+// to rawFieldDocs (the unstripped godoc for each field, keyed by
+// "TypeName.FieldName", as returned by ParseDocumentationFromReadersWithPolicy).
+func NewVerifierWithPolicy(packageName string, docsForTypes []kruntime.KubeTypes, enforceComments bool, rawFieldDocs map[string]string, cfg PolicyConfig) *Verifier {
+	return &Verifier{packageName: packageName, docsForTypes: docsForTypes, enforceComments: enforceComments, rawFieldDocs: rawFieldDocs, cfg: cfg}
+}
+
+// Verify checks docsForTypes against the existing generated Go source read
+// from existing, then applies v's policy rules to v.rawFieldDocs.
+func (v *Verifier) Verify(existing io.Reader) error {
+	buf := bytes.NewBuffer(nil)
+	rc, err := kruntime.VerifySwaggerDocsExist(v.docsForTypes, buf)
+	if err != nil {
+		return fmt.Errorf("could not verify existing docs: %w", err)
+	}
+	if rc > 0 {
+		if v.enforceComments {
+			return fmt.Errorf("missing swagger docs for the following %d fields:\n%s", rc, buf.String())
+		}
+		klog.Warningf("Existing swagger docs are missing %d entries:\n%s", rc, buf.String())
+	}
+
+	data, err := ioutil.ReadAll(existing)
+	if err != nil {
+		return fmt.Errorf("error reading existing swagger docs: %w", err)
+	}
+
+	// generateSwaggerDocs works on a copy, so v.docsForTypes stays intact for
+	// later Verify calls.
+	generatedData, err := generateSwaggerDocs(v.packageName, v.docsForTypes)
+	if err != nil {
+		return fmt.Errorf("error generating swagger docs: %w", err)
+	}
+
+	if string(data) != string(generatedData) {
+		return fmt.Errorf("swagger docs are out of date, please regenerate the swagger docs")
+	}
+
+	return applyPolicy(v.cfg, v.rawFieldDocs)
+}
+
+// generateSwaggerDocsFromGlob implements the on-disk input side of
+// generation on top of Generator: it globs
+// typesGlob within dir, parses every match, and returns a Generator for the
+// combined documentation. CLI entry points that want the historical
+// file-glob + file I/O behavior should call this instead of wiring the glob
+// themselves.
+func generateSwaggerDocsFromGlob(packageName, dir string) (*Generator, error) {
+	matches, err := filepath.Glob(filepath.Join(dir, typesGlob))
+	if err != nil {
+		return nil, fmt.Errorf("error globbing %s: %w", typesGlob, err)
+	}
+
+	srcs := make([]io.Reader, 0, len(matches))
+	for _, match := range matches {
+		f, err := ioutil.ReadFile(match)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %w", match, err)
+		}
+		srcs = append(srcs, bytes.NewReader(f))
+	}
+
+	docs, err := ParseDocumentationFromReaders(srcs)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewGenerator(packageName, docs), nil
+}
@@ -0,0 +1,206 @@
+package swaggerdocs
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+)
+
+func readersOf(srcs ...string) []io.Reader {
+	readers := make([]io.Reader, len(srcs))
+	for i, src := range srcs {
+		readers[i] = strings.NewReader(src)
+	}
+	return readers
+}
+
+func findKubeType(docs []kruntime.KubeTypes, name string) kruntime.KubeTypes {
+	for _, kt := range docs {
+		if len(kt) > 0 && kt[0].Name == name {
+			return kt
+		}
+	}
+	return nil
+}
+
+func TestParseDocumentationFromReadersEmbeddedFields(t *testing.T) {
+	src := `package v1
+
+// TypeMeta describes an individual object.
+type TypeMeta struct {
+	// Kind is a string value representing the object's type.
+	Kind string
+}
+
+// Pod is a collection of containers.
+type Pod struct {
+	// TypeMeta is the standard type metadata.
+	TypeMeta
+	// Name is the pod's name.
+	Name string
+}
+`
+
+	docs, err := ParseDocumentationFromReaders(readersOf(src))
+	if err != nil {
+		t.Fatalf("ParseDocumentationFromReaders: %v", err)
+	}
+
+	pod := findKubeType(docs, "Pod")
+	if pod == nil {
+		t.Fatal("no KubeTypes entry for Pod")
+	}
+
+	wantFields := map[string]string{
+		"Kind": "Kind is a string value representing the object's type.",
+		"Name": "Name is the pod's name.",
+	}
+	gotFields := map[string]string{}
+	for _, row := range pod[1:] {
+		gotFields[row.Name] = row.Doc
+	}
+	for field, doc := range wantFields {
+		if gotFields[field] != doc {
+			t.Errorf("field %q doc = %q, want %q", field, gotFields[field], doc)
+		}
+	}
+	if _, ok := gotFields["TypeMeta"]; ok {
+		t.Error("TypeMeta embedding should be flattened away, not documented as its own field")
+	}
+}
+
+func TestParseDocumentationFromReadersEmbeddedFieldsAcrossSources(t *testing.T) {
+	typeMetaSrc := `package v1
+
+// TypeMeta describes an individual object.
+type TypeMeta struct {
+	// Kind is a string value representing the object's type.
+	Kind string
+}
+`
+	podSrc := `package v1
+
+// Pod is a collection of containers.
+type Pod struct {
+	// TypeMeta is the standard type metadata.
+	TypeMeta
+	// Name is the pod's name.
+	Name string
+}
+`
+
+	docs, err := ParseDocumentationFromReaders(readersOf(typeMetaSrc, podSrc))
+	if err != nil {
+		t.Fatalf("ParseDocumentationFromReaders: %v", err)
+	}
+
+	pod := findKubeType(docs, "Pod")
+	if pod == nil {
+		t.Fatal("no KubeTypes entry for Pod")
+	}
+
+	wantFields := map[string]string{
+		"Kind": "Kind is a string value representing the object's type.",
+		"Name": "Name is the pod's name.",
+	}
+	gotFields := map[string]string{}
+	for _, row := range pod[1:] {
+		gotFields[row.Name] = row.Doc
+	}
+	for field, doc := range wantFields {
+		if gotFields[field] != doc {
+			t.Errorf("field %q doc = %q, want %q", field, gotFields[field], doc)
+		}
+	}
+	if _, ok := gotFields["TypeMeta"]; ok {
+		t.Error("TypeMeta embedding declared in a sibling source should be flattened away, not documented as its own field")
+	}
+}
+
+func TestParseDocumentationFromReadersWithResolversInterfaceAndGeneric(t *testing.T) {
+	src := `package v1
+
+// Extension holds arbitrary extension data.
+type Extension struct {
+	// Raw is the raw extension payload.
+	Raw interface{}
+}
+
+// List is a generic list of T.
+type List[T any] struct {
+	// Items are the list elements.
+	Items []T
+}
+`
+
+	docs, fieldTypes, err := ParseDocumentationFromReadersWithResolvers(readersOf(src), nil)
+	if err != nil {
+		t.Fatalf("ParseDocumentationFromReadersWithResolvers: %v", err)
+	}
+	if findKubeType(docs, "Extension") == nil {
+		t.Fatal("no KubeTypes entry for Extension")
+	}
+
+	ft, ok := fieldTypes["Extension.Raw"]
+	if !ok {
+		t.Fatal("fieldTypes missing Extension.Raw")
+	}
+	if ft.OpenAPIType != "object" {
+		t.Errorf("Extension.Raw OpenAPIType = %q, want %q", ft.OpenAPIType, "object")
+	}
+}
+
+func TestParseDocumentationFromReadersWithResolversCustomResolver(t *testing.T) {
+	src := `package v1
+
+// Event records something that happened.
+type Event struct {
+	// When is the time the event occurred.
+	When Time
+}
+`
+	resolver := func(goType string) string {
+		if goType == "Time" {
+			return "string"
+		}
+		return ""
+	}
+
+	_, fieldTypes, err := ParseDocumentationFromReadersWithResolvers(readersOf(src), []TypeResolver{resolver})
+	if err != nil {
+		t.Fatalf("ParseDocumentationFromReadersWithResolvers: %v", err)
+	}
+
+	ft, ok := fieldTypes["Event.When"]
+	if !ok {
+		t.Fatal("fieldTypes missing Event.When")
+	}
+	if ft.OpenAPIType != "string" {
+		t.Errorf("Event.When OpenAPIType = %q, want %q", ft.OpenAPIType, "string")
+	}
+}
+
+func TestParseDocumentationFromReadersWithPolicyRawFieldDocs(t *testing.T) {
+	src := `package v1
+
+// Pod is a collection of containers.
+type Pod struct {
+	// TODO: document this properly
+	Name string
+}
+`
+	_, _, rawFieldDocs, err := ParseDocumentationFromReadersWithPolicy(readersOf(src), nil)
+	if err != nil {
+		t.Fatalf("ParseDocumentationFromReadersWithPolicy: %v", err)
+	}
+
+	raw, ok := rawFieldDocs["Pod.Name"]
+	if !ok {
+		t.Fatal("rawFieldDocs missing Pod.Name")
+	}
+	if !strings.Contains(raw, "TODO") {
+		t.Errorf("rawFieldDocs[Pod.Name] = %q, want the unstripped TODO marker preserved", raw)
+	}
+}
@@ -0,0 +1,109 @@
+package swaggerdocs
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestGeneratorWriteToRepeatedGoOutput(t *testing.T) {
+	gen := NewGenerator("v1", widgetDocs())
+
+	var first, second bytes.Buffer
+	if err := gen.WriteTo(GoOutputType, &first); err != nil {
+		t.Fatalf("WriteTo (first call): %v", err)
+	}
+	if err := gen.WriteTo(GoOutputType, &second); err != nil {
+		t.Fatalf("WriteTo (second call): %v", err)
+	}
+
+	if first.String() != second.String() {
+		t.Errorf("repeated WriteTo(%q) calls produced different output:\nfirst:\n%s\nsecond:\n%s", GoOutputType, first.String(), second.String())
+	}
+	if strings.Contains(second.String(), "func () SwaggerDoc") {
+		t.Error("second WriteTo output has a receiver-less SwaggerDoc method; docsForTypes was mutated by the first call")
+	}
+}
+
+func TestGeneratorWriteToMixedOutputTypes(t *testing.T) {
+	gen := NewGenerator("v1", widgetDocs())
+
+	var goOut bytes.Buffer
+	if err := gen.WriteTo(GoOutputType, &goOut); err != nil {
+		t.Fatalf("WriteTo(%q): %v", GoOutputType, err)
+	}
+
+	var jsonOut bytes.Buffer
+	if err := gen.WriteTo("json", &jsonOut); err != nil {
+		t.Fatalf("WriteTo(json): %v", err)
+	}
+	if !strings.Contains(jsonOut.String(), `"type": "Widget"`) {
+		t.Errorf("json output missing the Widget type name (go emit pass likely blanked it): %s", jsonOut.String())
+	}
+}
+
+func TestVerifierVerifyAcceptsItsOwnOutput(t *testing.T) {
+	docsForTypes := widgetDocs()
+
+	gen := NewGenerator("v1", docsForTypes)
+	var existing bytes.Buffer
+	if err := gen.WriteTo(GoOutputType, &existing); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+
+	verifier := NewVerifier("v1", docsForTypes, true)
+	if err := verifier.Verify(bytes.NewReader(existing.Bytes())); err != nil {
+		t.Errorf("Verify against the Generator's own output: %v", err)
+	}
+
+	// A second Verify call on the same Verifier must behave identically;
+	// docsForTypes must not have been corrupted by the first call.
+	if err := verifier.Verify(bytes.NewReader(existing.Bytes())); err != nil {
+		t.Errorf("Verify (second call): %v", err)
+	}
+}
+
+func TestVerifierVerifyWithPolicyAppliesRules(t *testing.T) {
+	docsForTypes := widgetDocs()
+	rawFieldDocs := map[string]string{"Widget.Name": "TODO: fill this in"}
+	cfg := PolicyConfig{DisallowTODOMarkers: SeverityError}
+
+	gen := NewGenerator("v1", docsForTypes)
+	var existing bytes.Buffer
+	if err := gen.WriteTo(GoOutputType, &existing); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+
+	verifier := NewVerifierWithPolicy("v1", docsForTypes, false, rawFieldDocs, cfg)
+	err := verifier.Verify(bytes.NewReader(existing.Bytes()))
+	if err == nil {
+		t.Fatal("Verify did not report the TODO marker policy violation")
+	}
+	if !strings.Contains(err.Error(), "TODO") {
+		t.Errorf("Verify error = %q, want it to mention the TODO violation", err)
+	}
+}
+
+func TestGenerateSwaggerDocsFromGlobWriteToRepeated(t *testing.T) {
+	dir := t.TempDir()
+	writeTypesFile(t, dir, validTypesSrc)
+
+	gen, err := generateSwaggerDocsFromGlob("v1", dir)
+	if err != nil {
+		t.Fatalf("generateSwaggerDocsFromGlob: %v", err)
+	}
+
+	var goOut, jsonOut bytes.Buffer
+	if err := gen.WriteTo(GoOutputType, &goOut); err != nil {
+		t.Fatalf("WriteTo(%q): %v", GoOutputType, err)
+	}
+	if err := gen.WriteTo("json", &jsonOut); err != nil {
+		t.Fatalf("WriteTo(json): %v", err)
+	}
+	if !strings.Contains(jsonOut.String(), `"type": "Widget"`) {
+		t.Errorf("json output missing the Widget type name: %s", jsonOut.String())
+	}
+	if goOut.Len() == 0 {
+		t.Error("go output is empty")
+	}
+}
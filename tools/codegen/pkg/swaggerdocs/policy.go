@@ -0,0 +1,148 @@
+package swaggerdocs
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"k8s.io/klog/v2"
+)
+
+// Severity controls how a PolicyRule violation is reported.
+type Severity string
+
+const (
+	// SeverityError fails verifySwaggerDocs when the rule is violated.
+	SeverityError Severity = "error"
+	// SeverityWarn logs the violation via klog but does not fail verification.
+	SeverityWarn Severity = "warn"
+	// SeverityOff disables the rule entirely.
+	SeverityOff Severity = "off"
+)
+
+// PolicyConfig configures the house-style rules applied to field comments by
+// enforcePolicy. The zero value disables every rule except MinCommentLength,
+// which defaults to 0 (no minimum).
+type PolicyConfig struct {
+	// MinCommentLength is the shortest comment allowed for an exported field,
+	// measured after the leading field name is stripped. A value of 0 skips
+	// this rule.
+	MinCommentLength int
+	// MinCommentLengthSeverity is MinCommentLength's severity. The zero
+	// value means SeverityError, matching the minimum-length check's
+	// original, non-configurable behavior; set SeverityWarn or SeverityOff
+	// to relax it once MinCommentLength is in use.
+	MinCommentLengthSeverity Severity
+	// RequireFieldNamePrefix requires the comment to start with the field
+	// name, matching the convention `FieldName is/are ...`.
+	RequireFieldNamePrefix Severity
+	// DisallowTODOMarkers flags `TODO(...)`/`TODO:` markers left on exported
+	// API fields instead of being resolved before merge.
+	DisallowTODOMarkers Severity
+	// RequireExplanationAfterCutoff requires a non-empty sentence following a
+	// `---` cutoff marker, rather than leaving the cutoff dangling.
+	RequireExplanationAfterCutoff Severity
+}
+
+// policyViolation is a single rule violation collected by enforcePolicy.
+type policyViolation struct {
+	severity Severity
+	message  string
+}
+
+// enabled reports whether s designates an active rule. The zero value ("")
+// and SeverityOff both mean "rule disabled", so PolicyConfig's zero value
+// disables every Severity-gated rule as documented.
+func (s Severity) enabled() bool {
+	return s != "" && s != SeverityOff
+}
+
+// enforcePolicy applies cfg's rules to the raw, unstripped godoc for each
+// field in rawFieldDocs (keyed by "Type.Field") and returns the violations
+// found. Rules left at their zero value or set to SeverityOff are skipped.
+func enforcePolicy(cfg PolicyConfig, rawFieldDocs map[string]string) []policyViolation {
+	var violations []policyViolation
+
+	for key, raw := range rawFieldDocs {
+		field := key
+		if idx := strings.LastIndex(key, "."); idx >= 0 {
+			field = key[idx+1:]
+		}
+
+		body := raw
+		if idx := strings.Index(raw, "---"); idx >= 0 {
+			cutoff := strings.TrimSpace(raw[idx+len("---"):])
+			if cfg.RequireExplanationAfterCutoff.enabled() && cutoff == "" {
+				violations = append(violations, policyViolation{
+					severity: cfg.RequireExplanationAfterCutoff,
+					message:  fmt.Sprintf("%s: --- cutoff has no explanatory sentence after it", key),
+				})
+			}
+			body = raw[:idx]
+		}
+		body = strings.TrimSpace(body)
+
+		if cfg.DisallowTODOMarkers.enabled() && strings.Contains(body, "TODO") {
+			violations = append(violations, policyViolation{
+				severity: cfg.DisallowTODOMarkers,
+				message:  fmt.Sprintf("%s: TODO marker left on an exported API field comment", key),
+			})
+		}
+
+		if cfg.RequireFieldNamePrefix.enabled() && body != "" && !strings.HasPrefix(body, field) {
+			violations = append(violations, policyViolation{
+				severity: cfg.RequireFieldNamePrefix,
+				message:  fmt.Sprintf("%s: comment must start with the field name %q", key, field),
+			})
+		}
+
+		if cfg.MinCommentLength > 0 {
+			trimmed := strings.TrimPrefix(body, field)
+			trimmed = strings.TrimSpace(trimmed)
+			if len(trimmed) < cfg.MinCommentLength {
+				severity := cfg.MinCommentLengthSeverity
+				if severity == "" {
+					severity = SeverityError
+				}
+				if severity != SeverityOff {
+					violations = append(violations, policyViolation{
+						severity: severity,
+						message:  fmt.Sprintf("%s: comment shorter than the required %d characters", key, cfg.MinCommentLength),
+					})
+				}
+			}
+		}
+	}
+
+	return violations
+}
+
+// applyPolicy runs enforcePolicy over rawFieldDocs and turns the result into
+// the error verifySwaggerDocs and Verifier.Verify return: SeverityWarn
+// violations are logged via klog, and the call fails only if at least one
+// SeverityError violation was found. enforceComments is handled separately
+// by the missing-doc check (kruntime.VerifySwaggerDocsExist), so cfg covers
+// the remaining house-style rules.
+func applyPolicy(cfg PolicyConfig, rawFieldDocs map[string]string) error {
+	violations := enforcePolicy(cfg, rawFieldDocs)
+	if len(violations) == 0 {
+		return nil
+	}
+
+	var errBuf bytes.Buffer
+	failed := false
+	for _, v := range violations {
+		switch v.severity {
+		case SeverityError:
+			failed = true
+			fmt.Fprintf(&errBuf, "%s\n", v.message)
+		case SeverityWarn:
+			klog.Warningf("swagger doc policy: %s", v.message)
+		}
+	}
+
+	if failed {
+		return fmt.Errorf("swagger doc comments violate house style:\n%s", errBuf.String())
+	}
+	return nil
+}
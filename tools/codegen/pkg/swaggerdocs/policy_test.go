@@ -0,0 +1,144 @@
+package swaggerdocs
+
+import "testing"
+
+func TestSeverityEnabled(t *testing.T) {
+	cases := []struct {
+		severity Severity
+		want     bool
+	}{
+		{"", false},
+		{SeverityOff, false},
+		{SeverityError, true},
+		{SeverityWarn, true},
+	}
+	for _, c := range cases {
+		if got := c.severity.enabled(); got != c.want {
+			t.Errorf("Severity(%q).enabled() = %v, want %v", c.severity, got, c.want)
+		}
+	}
+}
+
+func TestEnforcePolicyRequireFieldNamePrefix(t *testing.T) {
+	cfg := PolicyConfig{RequireFieldNamePrefix: SeverityError}
+	rawFieldDocs := map[string]string{"Widget.Name": "the widget's name."}
+
+	violations := enforcePolicy(cfg, rawFieldDocs)
+	if len(violations) != 1 {
+		t.Fatalf("len(violations) = %d, want 1", len(violations))
+	}
+	if violations[0].severity != SeverityError {
+		t.Errorf("violations[0].severity = %q, want %q", violations[0].severity, SeverityError)
+	}
+}
+
+func TestEnforcePolicyRequireFieldNamePrefixOffByDefault(t *testing.T) {
+	var cfg PolicyConfig
+	rawFieldDocs := map[string]string{"Widget.Name": "the widget's name."}
+
+	if violations := enforcePolicy(cfg, rawFieldDocs); len(violations) != 0 {
+		t.Errorf("zero-value PolicyConfig reported violations: %+v", violations)
+	}
+}
+
+func TestEnforcePolicyDisallowTODOMarkers(t *testing.T) {
+	cfg := PolicyConfig{DisallowTODOMarkers: SeverityWarn}
+	rawFieldDocs := map[string]string{"Widget.Name": "Name is the widget's name. TODO(alice): fill this in"}
+
+	violations := enforcePolicy(cfg, rawFieldDocs)
+	if len(violations) != 1 {
+		t.Fatalf("len(violations) = %d, want 1", len(violations))
+	}
+	if violations[0].severity != SeverityWarn {
+		t.Errorf("violations[0].severity = %q, want %q", violations[0].severity, SeverityWarn)
+	}
+}
+
+func TestEnforcePolicyRequireExplanationAfterCutoff(t *testing.T) {
+	cfg := PolicyConfig{RequireExplanationAfterCutoff: SeverityError}
+
+	withExplanation := map[string]string{"Widget.Name": "Name is the widget's name.\n---\nThis is deprecated, see Widget.ID instead."}
+	if violations := enforcePolicy(cfg, withExplanation); len(violations) != 0 {
+		t.Errorf("cutoff with explanation reported violations: %+v", violations)
+	}
+
+	dangling := map[string]string{"Widget.Name": "Name is the widget's name.\n---\n"}
+	violations := enforcePolicy(cfg, dangling)
+	if len(violations) != 1 {
+		t.Fatalf("len(violations) = %d, want 1", len(violations))
+	}
+	if violations[0].severity != SeverityError {
+		t.Errorf("violations[0].severity = %q, want %q", violations[0].severity, SeverityError)
+	}
+}
+
+func TestEnforcePolicyMinCommentLength(t *testing.T) {
+	cfg := PolicyConfig{MinCommentLength: 20}
+	rawFieldDocs := map[string]string{"Widget.Name": "Name is it."}
+
+	violations := enforcePolicy(cfg, rawFieldDocs)
+	if len(violations) != 1 {
+		t.Fatalf("len(violations) = %d, want 1", len(violations))
+	}
+	if violations[0].severity != SeverityError {
+		t.Errorf("violations[0].severity = %q, want %q (default when MinCommentLengthSeverity is unset)", violations[0].severity, SeverityError)
+	}
+}
+
+func TestEnforcePolicyMinCommentLengthSeverityWarn(t *testing.T) {
+	cfg := PolicyConfig{MinCommentLength: 20, MinCommentLengthSeverity: SeverityWarn}
+	rawFieldDocs := map[string]string{"Widget.Name": "Name is it."}
+
+	violations := enforcePolicy(cfg, rawFieldDocs)
+	if len(violations) != 1 {
+		t.Fatalf("len(violations) = %d, want 1", len(violations))
+	}
+	if violations[0].severity != SeverityWarn {
+		t.Errorf("violations[0].severity = %q, want %q", violations[0].severity, SeverityWarn)
+	}
+}
+
+func TestEnforcePolicyMinCommentLengthSeverityOff(t *testing.T) {
+	cfg := PolicyConfig{MinCommentLength: 20, MinCommentLengthSeverity: SeverityOff}
+	rawFieldDocs := map[string]string{"Widget.Name": "Name is it."}
+
+	if violations := enforcePolicy(cfg, rawFieldDocs); len(violations) != 0 {
+		t.Errorf("MinCommentLengthSeverity: SeverityOff reported violations: %+v", violations)
+	}
+}
+
+func TestEnforcePolicyMinCommentLengthPasses(t *testing.T) {
+	cfg := PolicyConfig{MinCommentLength: 5}
+	rawFieldDocs := map[string]string{"Widget.Name": "Name is the widget's name."}
+
+	if violations := enforcePolicy(cfg, rawFieldDocs); len(violations) != 0 {
+		t.Errorf("long-enough comment reported violations: %+v", violations)
+	}
+}
+
+func TestApplyPolicyFailsOnlyOnError(t *testing.T) {
+	cfg := PolicyConfig{DisallowTODOMarkers: SeverityWarn}
+	rawFieldDocs := map[string]string{"Widget.Name": "Name TODO: fill this in"}
+
+	if err := applyPolicy(cfg, rawFieldDocs); err != nil {
+		t.Errorf("applyPolicy with only warn-level violations returned an error: %v", err)
+	}
+}
+
+func TestApplyPolicyFailsOnError(t *testing.T) {
+	cfg := PolicyConfig{DisallowTODOMarkers: SeverityError}
+	rawFieldDocs := map[string]string{"Widget.Name": "Name TODO: fill this in"}
+
+	if err := applyPolicy(cfg, rawFieldDocs); err == nil {
+		t.Error("applyPolicy with an error-level violation returned nil")
+	}
+}
+
+func TestApplyPolicyNoViolations(t *testing.T) {
+	cfg := PolicyConfig{DisallowTODOMarkers: SeverityError, RequireFieldNamePrefix: SeverityError}
+	rawFieldDocs := map[string]string{"Widget.Name": "Name is the widget's name."}
+
+	if err := applyPolicy(cfg, rawFieldDocs); err != nil {
+		t.Errorf("applyPolicy with no violations returned an error: %v", err)
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"fmt"
 	"go/format"
 	"io/ioutil"
+	"os"
+	"path/filepath"
 	"reflect"
 
 	kruntime "k8s.io/apimachinery/pkg/runtime"
@@ -35,11 +37,18 @@ const (
 
 	// typesGlob is a glob used to find all types files within a group version package.
 	typesGlob = "types*.go"
+
+	// GoOutputType is the OutputTypes value for the existing Go source
+	// output produced by generateSwaggerDocs.
+	GoOutputType = "go"
 )
 
-// verifySwaggerDocs reads the existing swagger documentation and verifies that the content
-// is up to date.
-func verifySwaggerDocs(packageName, filePath string, docsForTypes []kruntime.KubeTypes, enforceComments bool) error {
+// verifySwaggerDocs reads the existing swagger documentation and verifies
+// that the content is up to date, then applies cfg's house-style rules to
+// rawFieldDocs, the unstripped godoc for each field keyed by "Type.Field".
+// enforceComments is a special case of cfg: it governs only the missing-doc
+// check below (kruntime.VerifySwaggerDocsExist), independent of cfg's rules.
+func verifySwaggerDocs(packageName, filePath string, docsForTypes []kruntime.KubeTypes, rawFieldDocs map[string]string, enforceComments bool, cfg PolicyConfig) error {
 	// Verify that every field has a doc string.
 	buf := bytes.NewBuffer(nil)
 	rc, err := kruntime.VerifySwaggerDocsExist(docsForTypes, buf)
@@ -70,7 +79,7 @@ func verifySwaggerDocs(packageName, filePath string, docsForTypes []kruntime.Kub
 		return errors.New("swagger docs are out of date, please regenerate the swagger docs")
 	}
 
-	return nil
+	return applyPolicy(cfg, rawFieldDocs)
 }
 
 // generateSwaggerDocs generates swagger documentation and writes it to the output
@@ -81,7 +90,11 @@ func generateSwaggerDocs(packageName string, docsForTypes []kruntime.KubeTypes)
 	buf.WriteString(fmt.Sprintf("package %s\n", packageName))
 	buf.WriteString(headerContent)
 
-	if err := kruntime.WriteSwaggerDocFunc(docsForTypes, buf); err != nil {
+	// kruntime.WriteSwaggerDocFunc mutates its argument in place (it blanks
+	// kubeType[0].Name after reading it), so callers that keep docsForTypes
+	// around for a later emit or a later WriteTo call must not see that
+	// mutation. Clone before handing it off.
+	if err := kruntime.WriteSwaggerDocFunc(cloneKubeTypes(docsForTypes), buf); err != nil {
 		return nil, fmt.Errorf("error generating swagger docs for types: %w", err)
 	}
 
@@ -95,3 +108,55 @@ func generateSwaggerDocs(packageName string, docsForTypes []kruntime.KubeTypes)
 
 	return formattedOut, nil
 }
+
+// cloneKubeTypes returns a copy of docsForTypes whose KubeTypes slices don't
+// share a backing array with the originals, so writing through the copy
+// (as kruntime.WriteSwaggerDocFunc does) can't mutate the caller's data.
+// Pair is a plain value type, so copying each KubeTypes's backing array is
+// sufficient; nothing deeper is needed.
+func cloneKubeTypes(docsForTypes []kruntime.KubeTypes) []kruntime.KubeTypes {
+	cloned := make([]kruntime.KubeTypes, len(docsForTypes))
+	for i, kt := range docsForTypes {
+		cloned[i] = append(kruntime.KubeTypes(nil), kt...)
+	}
+	return cloned
+}
+
+// writeSwaggerDocs generates swagger documentation for each of outputTypes and
+// writes the result into outputDir. outputTypes may contain GoOutputType,
+// which is written to filepath.Join(outputDir, DefaultOutputFileName), and
+// any key registered in emittersByOutputType (currently "json" and "yaml"),
+// which is written to filepath.Join(outputDir, outputFileName(outputType)).
+func writeSwaggerDocs(packageName, outputDir string, docsForTypes []kruntime.KubeTypes, outputTypes []string) error {
+	for _, outputType := range outputTypes {
+		if outputType == GoOutputType {
+			data, err := generateSwaggerDocs(packageName, docsForTypes)
+			if err != nil {
+				return err
+			}
+			if err := ioutil.WriteFile(filepath.Join(outputDir, DefaultOutputFileName), data, 0644); err != nil {
+				return fmt.Errorf("error writing swagger docs: %w", err)
+			}
+			continue
+		}
+
+		emitter, ok := emittersByOutputType[outputType]
+		if !ok {
+			return fmt.Errorf("unknown swagger doc output type %q", outputType)
+		}
+
+		f, err := os.Create(filepath.Join(outputDir, outputFileName(outputType)))
+		if err != nil {
+			return fmt.Errorf("error creating swagger docs file: %w", err)
+		}
+		err = emitter.Emit(packageName, docsForTypes, f)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return fmt.Errorf("error writing %s swagger docs: %w", outputType, err)
+		}
+	}
+
+	return nil
+}
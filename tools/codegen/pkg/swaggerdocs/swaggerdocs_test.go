@@ -0,0 +1,71 @@
+package swaggerdocs
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	kruntime "k8s.io/apimachinery/pkg/runtime"
+)
+
+func widgetDocs() []kruntime.KubeTypes {
+	return []kruntime.KubeTypes{
+		{
+			{Name: "Widget", Doc: "Widget is a simple documented type."},
+			{Name: "Name", Doc: "Name is the widget's name."},
+		},
+	}
+}
+
+func TestWriteSwaggerDocsMixedOutputTypesDoNotCorruptEachOther(t *testing.T) {
+	dir := t.TempDir()
+	docsForTypes := widgetDocs()
+
+	// GoOutputType must come first: it's the branch that calls
+	// kruntime.WriteSwaggerDocFunc, which mutates its argument in place.
+	if err := writeSwaggerDocs("v1", dir, docsForTypes, []string{GoOutputType, "json", "yaml"}); err != nil {
+		t.Fatalf("writeSwaggerDocs: %v", err)
+	}
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, outputFileName("json")))
+	if err != nil {
+		t.Fatalf("reading json output: %v", err)
+	}
+
+	var got docPackage
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal json output: %v", err)
+	}
+	if len(got.Types) != 1 {
+		t.Fatalf("len(Types) = %d, want 1", len(got.Types))
+	}
+	if got.Types[0].Type != "Widget" {
+		t.Errorf("Types[0].Type = %q, want %q (the go emit pass should not have blanked it)", got.Types[0].Type, "Widget")
+	}
+}
+
+func TestGenerateSwaggerDocsDoesNotMutateInput(t *testing.T) {
+	docsForTypes := widgetDocs()
+
+	first, err := generateSwaggerDocs("v1", docsForTypes)
+	if err != nil {
+		t.Fatalf("generateSwaggerDocs (first call): %v", err)
+	}
+	if docsForTypes[0][0].Name != "Widget" {
+		t.Fatalf("docsForTypes[0][0].Name = %q after one generateSwaggerDocs call, want %q unchanged", docsForTypes[0][0].Name, "Widget")
+	}
+
+	// Second and third calls on the same slice must render identically to the first.
+	second, err := generateSwaggerDocs("v1", docsForTypes)
+	if err != nil {
+		t.Fatalf("generateSwaggerDocs (second call): %v", err)
+	}
+	third, err := generateSwaggerDocs("v1", docsForTypes)
+	if err != nil {
+		t.Fatalf("generateSwaggerDocs (third call): %v", err)
+	}
+	if string(first) != string(second) || string(first) != string(third) {
+		t.Errorf("repeated generateSwaggerDocs calls on the same input produced different output:\nfirst:\n%s\nsecond:\n%s\nthird:\n%s", first, second, third)
+	}
+}
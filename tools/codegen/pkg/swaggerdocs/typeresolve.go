@@ -0,0 +1,75 @@
+package swaggerdocs
+
+import (
+	"go/ast"
+)
+
+// TypeResolver maps a Go type expression, rendered as source text (e.g.
+// "metav1.Time", "*int32", "map[string]string"), to the OpenAPI type name it
+// should be documented as. Resolvers are consulted in order; the first
+// non-empty result wins. Types with no matching resolver keep the parser's
+// built-in classification.
+type TypeResolver func(goType string) (openAPIType string)
+
+// exprString renders a type expression back to the source text a
+// TypeResolver expects to match against, e.g. "*metav1.Time".
+func exprString(expr ast.Expr) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t.Name
+	case *ast.StarExpr:
+		return "*" + exprString(t.X)
+	case *ast.SelectorExpr:
+		return exprString(t.X) + "." + t.Sel.Name
+	case *ast.ArrayType:
+		return "[]" + exprString(t.Elt)
+	case *ast.MapType:
+		return "map[" + exprString(t.Key) + "]" + exprString(t.Value)
+	case *ast.InterfaceType:
+		if t.Methods == nil || len(t.Methods.List) == 0 {
+			return "interface{}"
+		}
+		return "interface{...}"
+	case *ast.IndexExpr:
+		// A generic instantiation with a single type argument, e.g. Foo[T].
+		return exprString(t.X) + "[" + exprString(t.Index) + "]"
+	case *ast.IndexListExpr:
+		s := exprString(t.X) + "["
+		for i, idx := range t.Indices {
+			if i > 0 {
+				s += ","
+			}
+			s += exprString(idx)
+		}
+		return s + "]"
+	default:
+		return ""
+	}
+}
+
+// resolveType classifies expr as an OpenAPI type name, consulting resolvers
+// first and falling back to the built-in interface{}/any -> "object" rule.
+// It returns "" when no rule applies, meaning the caller should fall back to
+// its default (unresolved) behavior.
+func resolveType(expr ast.Expr, resolvers []TypeResolver) string {
+	rendered := exprString(expr)
+
+	for _, resolve := range resolvers {
+		if openAPIType := resolve(rendered); openAPIType != "" {
+			return openAPIType
+		}
+	}
+
+	switch t := expr.(type) {
+	case *ast.InterfaceType:
+		if t.Methods == nil || len(t.Methods.List) == 0 {
+			return "object"
+		}
+	case *ast.Ident:
+		if t.Name == "any" {
+			return "object"
+		}
+	}
+
+	return ""
+}